@@ -3,194 +3,133 @@ package main
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 
-	"github.com/gdamore/tcell/v2"
-	"github.com/rivo/tview"
-	"github.com/shirou/gopsutil/cpu"
-	"github.com/shirou/gopsutil/disk"
-	"github.com/shirou/gopsutil/mem"
-	"github.com/shirou/gopsutil/process"
+	"github.com/rohat1337/sysm/widgets"
+	"github.com/spf13/cobra"
 )
 
-// Pagination variables
-var (
-	processesPerPage = 10
-	currentPage      = 0
-	totalProcesses   = 0
-)
-
-// Fetch CPU usage stats
-func getCPUUsage() string {
-	percent, err := cpu.Percent(0, true)
+func parseRate(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
 	if err != nil {
-		return fmt.Sprintf("Error fetching CPU stats: %v", err)
-	}
-	cpuStats := "CPU Usage:\n"
-	for i, p := range percent {
-		cpuStats += fmt.Sprintf("Core %d: %.2f%%\n", i, p)
+		return 0, fmt.Errorf("invalid --rate %q: %w", s, err)
 	}
-	return cpuStats
-}
-
-// Fetch Memory usage stats
-func getMemoryUsage() string {
-	v, err := mem.VirtualMemory()
-	if err != nil {
-		return fmt.Sprintf("Error fetching memory stats: %v", err)
-	}
-	return fmt.Sprintf("Memory Usage: %.2f%%\nTotal: %v MB\nUsed: %v MB\nFree: %v MB",
-		v.UsedPercent, v.Total/1024/1024, v.Used/1024/1024, v.Free/1024/1024)
-}
-
-// Fetch Disk usage stats
-func getDiskUsage() string {
-	d, err := disk.Usage("/")
-	if err != nil {
-		return fmt.Sprintf("Error fetching disk stats: %v", err)
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid --rate %q: must be positive", s)
 	}
-	return fmt.Sprintf("Disk Usage: %.2f%%\nTotal: %v GB\nUsed: %v GB\nFree: %v GB",
-		d.UsedPercent, d.Total/1024/1024/1024, d.Used/1024/1024/1024, d.Free/1024/1024/1024)
+	return d, nil
 }
 
-// Fetch a paginated process list
-func getProcessList() ([]string, int) {
-	procs, err := process.Processes()
-	if err != nil {
-		return []string{fmt.Sprintf("Error fetching processes: %v", err)}, 0
-	}
-
-	totalProcesses = len(procs)
-	start := currentPage * processesPerPage
-	end := start + processesPerPage
-
-	if start >= totalProcesses {
-		currentPage = 0 // Reset to first page if out of bounds
-		start = 0
-		end = processesPerPage
-	}
-
-	if end > totalProcesses {
-		end = totalProcesses
-	}
+func main() {
+	var (
+		rate       string
+		colorName  string
+		configPath string
+		perCPU     bool
+		history    int
+		cpuInfo    bool
+		serveAddr  string
+		headless   bool
+	)
+
+	rootCmd := &cobra.Command{
+		Use:   "sysm",
+		Short: "A terminal system monitor",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := widgets.LoadConfig(configPath)
+			if err != nil {
+				return fmt.Errorf("loading config %s: %w", configPath, err)
+			}
 
-	var processList []string
-	for _, proc := range procs[start:end] {
-		name, _ := proc.Name()
-		cpuUsage, _ := proc.CPUPercent()
-		memUsage, _ := proc.MemoryPercent()
-		processList = append(processList, fmt.Sprintf("PID %d | %s | CPU: %.2f%% | Mem: %.2f%%", proc.Pid, name, cpuUsage, memUsage))
-	}
+			if cmd.Flags().Changed("color") {
+				cfg.Color = colorName
+			}
+			if cmd.Flags().Changed("percpu") {
+				cfg.PerCPU = perCPU
+			}
+			if cmd.Flags().Changed("rate") {
+				parsed, err := parseRate(rate)
+				if err != nil {
+					return err
+				}
+				cfg.Rate = parsed
+			}
 
-	return processList, totalProcesses
-}
+			scheme, ok := widgets.Colorschemes[cfg.Color]
+			if !ok {
+				scheme = widgets.Colorschemes[widgets.DefaultColorscheme]
+			}
 
-func main() {
-	// Create new tview app
-	app := tview.NewApplication()
-
-	// Create a flex layout
-	flex := tview.NewFlex().SetDirection(tview.FlexColumn)
-
-	// CPU, Memory, Disk stats
-	statsTextView := tview.NewTextView().
-		SetDynamicColors(true).
-		SetTextAlign(tview.AlignLeft).
-		SetText("Loading stats...")
-
-	// Process List Table
-	processTable := tview.NewTable().SetBorders(true)
-
-	// Function to update stats
-	updateStats := func() {
-		cpuStats := getCPUUsage()
-		memStats := getMemoryUsage()
-		diskStats := getDiskUsage()
-
-		statsTextView.SetText(fmt.Sprintf("[yellow]CPU:[white]\n%s[yellow]Memory:[white]\n%s\n[yellow]Disk:[white]\n%s",
-			cpuStats, memStats, diskStats))
-		app.QueueUpdateDraw(func() {})
-	}
+			hub := widgets.NewHub(cfg.Rate, history)
 
-	// Function to update process list with pagination
-	updateProcessTable := func() {
-		processTable.Clear()
-		processList, total := getProcessList()
-
-		// Add header
-		processTable.SetCell(0, 0, tview.NewTableCell("[yellow]PID").SetAlign(tview.AlignCenter))
-		processTable.SetCell(0, 1, tview.NewTableCell("[yellow]Name").SetAlign(tview.AlignCenter))
-		processTable.SetCell(0, 2, tview.NewTableCell("[yellow]CPU %").SetAlign(tview.AlignCenter))
-		processTable.SetCell(0, 3, tview.NewTableCell("[yellow]Mem %").SetAlign(tview.AlignCenter))
-
-		// Add processes
-		for i, proc := range processList {
-			// Split process string manually
-			parts := strings.Split(proc, " | ")
-			if len(parts) < 4 {
-				continue // Skip invalid entries
+			if headless {
+				if serveAddr == "" {
+					return fmt.Errorf("--headless requires --serve")
+				}
+				return runHeadless(hub, serveAddr)
 			}
 
-			processTable.SetCell(i+1, 0, tview.NewTableCell(parts[0]).SetAlign(tview.AlignCenter))
-			processTable.SetCell(i+1, 1, tview.NewTableCell(parts[1]).SetAlign(tview.AlignLeft))
-			processTable.SetCell(i+1, 2, tview.NewTableCell(parts[2]).SetAlign(tview.AlignCenter))
-			processTable.SetCell(i+1, 3, tview.NewTableCell(parts[3]).SetAlign(tview.AlignCenter))
-		}
+			if serveAddr != "" {
+				srv := widgets.NewMetricsServer(serveAddr, hub)
+				go func() {
+					if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+						log.Printf("metrics server: %v", err)
+					}
+				}()
+			}
 
-		// Footer for pagination info
-		paginationInfo := fmt.Sprintf("[yellow]Page %d/%d | %d Processes Total", currentPage+1, (total/processesPerPage)+1, total)
-		processTable.SetCell(len(processList)+1, 0, tview.NewTableCell(paginationInfo).SetAlign(tview.AlignLeft).SetSelectable(false))
+			app := widgets.NewApp(hub, widgets.Options{
+				CPUInfo:  cpuInfo,
+				PerCPU:   cfg.PerCPU,
+				PageSize: cfg.PageSize,
+				Scheme:   scheme,
+				Widgets:  cfg.Widgets,
+			})
+			return app.Run()
+		},
+	}
 
-		app.QueueUpdateDraw(func() {})
+	rootCmd.Flags().StringVar(&rate, "rate", "1s", "stats refresh interval (e.g. 500ms, 2s)")
+	rootCmd.Flags().StringVar(&colorName, "color", widgets.DefaultColorscheme, "colorscheme: default, monokai, solarized, nord")
+	rootCmd.Flags().StringVar(&configPath, "config", widgets.DefaultConfigPath(), "path to YAML config file")
+	rootCmd.Flags().BoolVar(&perCPU, "percpu", true, "show per-core CPU graphs instead of averaged")
+	rootCmd.Flags().IntVar(&history, "history", 180, "number of samples to keep for history graphs")
+	rootCmd.Flags().BoolVarP(&cpuInfo, "cpuinfo", "c", false, "show per-core user/sys/iowait/irq/softirq/steal breakdown")
+	rootCmd.Flags().StringVar(&serveAddr, "serve", "", "also serve /metrics and /metrics/prom on this address (e.g. :9090), in addition to the TUI")
+	rootCmd.Flags().BoolVar(&headless, "headless", false, "run as a standalone metrics exporter, with no TUI and no controlling terminal required (use with --serve)")
+
+	if err := rootCmd.Execute(); err != nil {
+		log.Fatal(err)
 	}
+}
 
-	// Set up auto-refresh for stats and process list
-	go func() {
-		for {
-			updateStats()
-			updateProcessTable()
-			time.Sleep(1 * time.Second) // Refresh every 2 seconds
+// runHeadless drives the Hub's sampling loop and the metrics HTTP server
+// without starting the tview UI, so sysm can run as a node-exporter-style
+// daemon on a box with no controlling terminal. It blocks until SIGINT or
+// SIGTERM, or until the HTTP server stops on its own (e.g. a listen error).
+func runHeadless(hub *widgets.Hub, addr string) error {
+	stop := make(chan struct{})
+	go hub.Run(stop)
+	defer close(stop)
+
+	srv := widgets.NewMetricsServer(addr, hub)
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- srv.ListenAndServe() }()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	select {
+	case err := <-serveErr:
+		if err != nil && err != http.ErrServerClosed {
+			return err
 		}
-	}()
-
-	// Handle SIGTERM and SIGINT for graceful exit
-	signalChannel := make(chan os.Signal, 1)
-	signal.Notify(signalChannel, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-signalChannel
-		log.Println("Shutting down...")
-		app.Stop()
-		os.Exit(0)
-	}()
-
-	// Layout UI
-	flex.AddItem(statsTextView, 0, 1, false) // System stats
-	flex.AddItem(processTable, 0, 1, true)   // Process list
-	// Keyboard input handling for pagination
-	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		switch event.Key() {
-		case tcell.KeyRight: // Next page
-			if (currentPage+1)*processesPerPage < totalProcesses {
-				currentPage++
-			}
-		case tcell.KeyLeft: // Previous page
-			if currentPage > 0 {
-				currentPage--
-			}
-		case tcell.KeyCtrlQ: // Quit application
-			app.Stop()
-			os.Exit(0)
-		}
-		return event
-	})
-
-	// Run the application
-	if err := app.SetRoot(flex, true).Run(); err != nil {
-		log.Fatalf("Error running app: %v", err)
+		return nil
+	case <-sigCh:
+		return srv.Close()
 	}
 }
@@ -0,0 +1,307 @@
+package widgets
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// perCoreThreshold is the core count above which the history view defaults
+// to an averaged graph instead of one sparkline per core.
+const perCoreThreshold = 8
+
+// Options configures an App's startup behavior.
+type Options struct {
+	// CPUInfo enables the detailed per-core user/sys/iowait/irq/softirq/steal
+	// breakdown table (the -c/--cpuinfo mode) instead of just the network and
+	// disk panels.
+	CPUInfo bool
+	// PerCPU starts the history graphs in per-core mode; otherwise they start
+	// averaged. Above perCoreThreshold cores they always start averaged.
+	PerCPU bool
+	// PageSize caps how many process rows are shown after sorting and
+	// filtering; 0 means unlimited.
+	PageSize int
+	// Scheme recolors every panel. The zero value falls back to
+	// Colorschemes[DefaultColorscheme].
+	Scheme Colorscheme
+	// Widgets lists optional panels to start visible: "network", "diskinfo",
+	// "cpuinfo". Unrecognized entries (e.g. the always-on "cpu"/"memory"/
+	// "disk"/"processes") are ignored.
+	Widgets []string
+}
+
+// App wires the Hub up to a tview UI: the existing stats/process panels plus
+// a row of history line graphs fed by the Hub's rolling buffers.
+type App struct {
+	tviewApp *tview.Application
+	hub      *Hub
+	pages    *tview.Pages
+
+	statsText   *tview.TextView
+	processView *ProcessView
+	graphsFlex  *tview.Flex
+
+	networkText  *tview.TextView
+	diskTable    *tview.Table
+	cpuInfoTable *tview.Table
+	cpuTimes     *cpuTimesTracker
+	panelsFlex   *tview.Flex
+	root         *tview.Flex
+
+	scheme Colorscheme
+
+	perCore     bool
+	expandCores bool
+	showNetwork bool
+	showDisks   bool
+	showCPUInfo bool
+}
+
+// NewApp builds the widget tree for a Hub. Call Run to start it.
+func NewApp(hub *Hub, opts Options) *App {
+	scheme := opts.Scheme
+	if (scheme == Colorscheme{}) {
+		scheme = Colorschemes[DefaultColorscheme]
+	}
+
+	a := &App{
+		tviewApp:    tview.NewApplication(),
+		hub:         hub,
+		perCore:     opts.PerCPU,
+		showCPUInfo: opts.CPUInfo,
+		cpuTimes:    newCPUTimesTracker(),
+		scheme:      scheme,
+	}
+
+	for _, w := range opts.Widgets {
+		switch w {
+		case "network":
+			a.showNetwork = true
+		case "diskinfo":
+			a.showDisks = true
+		case "cpuinfo":
+			a.showCPUInfo = true
+		}
+	}
+
+	a.statsText = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft).
+		SetText("Loading stats...")
+
+	a.pages = tview.NewPages()
+	a.processView = NewProcessView(a.tviewApp, a.pages, scheme, opts.PageSize)
+
+	a.graphsFlex = tview.NewFlex().SetDirection(tview.FlexColumn)
+	a.rebuildGraphs()
+
+	a.networkText = tview.NewTextView().
+		SetDynamicColors(true).
+		SetTextAlign(tview.AlignLeft).
+		SetText("Loading network stats...")
+	a.diskTable = tview.NewTable().SetBorders(true)
+	a.diskTable.SetBordersColor(scheme.Border)
+	updateDiskTable(a.diskTable, a.scheme, nil)
+
+	a.cpuInfoTable = tview.NewTable().SetBorders(true)
+	a.cpuInfoTable.SetBordersColor(scheme.Border)
+
+	a.panelsFlex = tview.NewFlex().SetDirection(tview.FlexColumn)
+
+	top := tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(a.statsText, 0, 1, false).
+		AddItem(a.processView.Table(), 0, 1, true)
+
+	a.root = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(top, 0, 2, true).
+		AddItem(a.graphsFlex, 0, 1, false)
+
+	a.pages.AddPage("main", a.root, true, true)
+
+	a.tviewApp.SetInputCapture(a.handleKey)
+	a.tviewApp.SetRoot(a.pages, true)
+	a.rebuildPanels()
+
+	return a
+}
+
+// rebuildPanels shows or hides the network/disk/cpuinfo panel row depending
+// on the showNetwork/showDisks/showCPUInfo toggles.
+func (a *App) rebuildPanels() {
+	a.panelsFlex.Clear()
+	if a.showNetwork {
+		a.panelsFlex.AddItem(a.networkText, 0, 1, false)
+	}
+	if a.showDisks {
+		a.panelsFlex.AddItem(a.diskTable, 0, 1, false)
+	}
+	if a.showCPUInfo {
+		a.panelsFlex.AddItem(a.cpuInfoTable, 0, 1, false)
+	}
+
+	a.root.RemoveItem(a.panelsFlex)
+	if a.showNetwork || a.showDisks || a.showCPUInfo {
+		a.root.AddItem(a.panelsFlex, 0, 1, false)
+	}
+}
+
+// rebuildGraphs (re)populates graphsFlex according to the current
+// per-core/averaged toggle and the Hub's latest core count.
+func (a *App) rebuildGraphs() {
+	a.graphsFlex.Clear()
+
+	showPerCore := a.perCore
+	if a.hub.CPUCoreCount() > perCoreThreshold {
+		showPerCore = a.expandCores
+	}
+	if showPerCore {
+		for i := 0; i < a.hub.CPUCoreCount(); i++ {
+			core := i
+			a.graphsFlex.AddItem(NewLineGraph(fmt.Sprintf("Core %d", core), func() []float64 {
+				return a.hub.CPUCoreHistory(core)
+			}), 0, 1, false)
+		}
+	} else {
+		a.graphsFlex.AddItem(NewLineGraph("CPU (avg)", a.hub.CPUAverageHistory), 0, 1, false)
+	}
+
+	a.graphsFlex.AddItem(NewLineGraph("Memory %", a.hub.MemHistory), 0, 1, false)
+	a.graphsFlex.AddItem(NewLineGraph("Swap %", a.hub.SwapHistory), 0, 1, false)
+	a.graphsFlex.AddItem(NewLineGraph("Disk Read B/s", func() []float64 {
+		read, _ := a.hub.DiskIOHistory()
+		return read
+	}), 0, 1, false)
+	a.graphsFlex.AddItem(NewLineGraph("Disk Write B/s", func() []float64 {
+		_, write := a.hub.DiskIOHistory()
+		return write
+	}), 0, 1, false)
+}
+
+// updateStats refreshes the text panel and any enabled extra panels from a
+// single Sample, rather than each panel polling gopsutil on its own. The
+// cpuinfo table is the exception: it reads cpu.Times itself on each call.
+func (a *App) updateStats(s Sample) {
+	cpuStats := getCPUUsage(s, a.scheme)
+	memStats := getMemoryUsage(s, a.scheme)
+	diskStats := getDiskUsage(s, a.scheme)
+
+	headerTag := tag(a.scheme.Header)
+	a.statsText.SetText(fmt.Sprintf("%sCPU:[white]\n%s%sMemory:[white]\n%s\n%sDisk:[white]\n%s",
+		headerTag, cpuStats, headerTag, memStats, headerTag, diskStats))
+
+	if a.showNetwork {
+		a.networkText.SetText(headerTag + getNetworkUsage(s))
+	}
+	if a.showDisks {
+		updateDiskTable(a.diskTable, a.scheme, s.DiskUsage)
+	}
+	if a.showCPUInfo {
+		if rows, err := a.cpuTimes.sample(); err == nil {
+			updateCPUInfoTable(a.cpuInfoTable, rows, a.scheme)
+		}
+	}
+}
+
+func (a *App) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	// A modal (search box, kill confirmation) is on top: let it own all
+	// input until it's dismissed.
+	if name, _ := a.pages.GetFrontPage(); name != "main" {
+		return event
+	}
+
+	if a.processView.HandleKey(event) {
+		return nil
+	}
+
+	switch event.Key() {
+	case tcell.KeyCtrlQ: // Quit application
+		a.tviewApp.Stop()
+		os.Exit(0)
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'v':
+			if a.hub.CPUCoreCount() > perCoreThreshold {
+				a.expandCores = !a.expandCores
+				a.tviewApp.QueueUpdateDraw(a.rebuildGraphs)
+			}
+		case 'n':
+			a.showNetwork = !a.showNetwork
+			a.tviewApp.QueueUpdateDraw(a.rebuildPanels)
+		case 'd':
+			a.showDisks = !a.showDisks
+			a.tviewApp.QueueUpdateDraw(a.rebuildPanels)
+		case 'c':
+			a.showCPUInfo = !a.showCPUInfo
+			a.tviewApp.QueueUpdateDraw(a.rebuildPanels)
+		}
+	}
+	return event
+}
+
+// Run starts the sampling loop, the refresh loop, and blocks running the
+// tview application until it exits. A panic anywhere in the main goroutine
+// is logged with its stack trace and the terminal is restored before the
+// panic is re-raised, instead of leaving the terminal in a broken state.
+func (a *App) Run() error {
+	defer func() {
+		if r := recover(); r != nil {
+			errLog.Printf("main goroutine panic: %v\n%s", r, debug.Stack())
+			a.tviewApp.Stop()
+			panic(r)
+		}
+	}()
+
+	stop := make(chan struct{})
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errLog.Printf("sampling goroutine panic: %v\n%s", r, debug.Stack())
+				a.tviewApp.Stop()
+				panic(r)
+			}
+		}()
+		a.hub.Run(stop)
+	}()
+	defer close(stop)
+
+	samples := a.hub.Subscribe()
+	go func() {
+		for s := range samples {
+			s := s
+			a.tviewApp.QueueUpdateDraw(func() {
+				a.updateStats(s)
+				a.processView.Refresh(s.Processes)
+			})
+		}
+	}()
+
+	// Seed the panels immediately so the UI isn't blank for the first tick.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		s := a.hub.Snapshot()
+		a.tviewApp.QueueUpdateDraw(func() {
+			a.updateStats(s)
+			a.processView.Refresh(s.Processes)
+		})
+	}()
+
+	// Handle SIGTERM and SIGINT for graceful exit
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signalChannel
+		log.Println("Shutting down...")
+		a.tviewApp.Stop()
+		os.Exit(0)
+	}()
+
+	return a.tviewApp.Run()
+}
@@ -0,0 +1,68 @@
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Colorscheme maps semantic UI roles to concrete terminal colors, so a
+// single --color flag recolors every panel instead of hunting down
+// hardcoded tview color tags.
+type Colorscheme struct {
+	Header    tcell.Color
+	Warning   tcell.Color
+	GaugeFill tcell.Color
+	Border    tcell.Color
+}
+
+// Colorschemes are the built-in schemes selectable via --color.
+var Colorschemes = map[string]Colorscheme{
+	"default": {
+		Header:    tcell.ColorYellow,
+		Warning:   tcell.ColorRed,
+		GaugeFill: tcell.ColorGreen,
+		Border:    tcell.ColorWhite,
+	},
+	"monokai": {
+		Header:    tcell.NewRGBColor(0xA6, 0xE2, 0x2E),
+		Warning:   tcell.NewRGBColor(0xF9, 0x26, 0x72),
+		GaugeFill: tcell.NewRGBColor(0x66, 0xD9, 0xEF),
+		Border:    tcell.NewRGBColor(0xF8, 0xF8, 0xF2),
+	},
+	"solarized": {
+		Header:    tcell.NewRGBColor(0xB5, 0x89, 0x00),
+		Warning:   tcell.NewRGBColor(0xDC, 0x32, 0x2F),
+		GaugeFill: tcell.NewRGBColor(0x2A, 0xA1, 0x98),
+		Border:    tcell.NewRGBColor(0x83, 0x94, 0x96),
+	},
+	"nord": {
+		Header:    tcell.NewRGBColor(0x88, 0xC0, 0xD0),
+		Warning:   tcell.NewRGBColor(0xBF, 0x61, 0x6A),
+		GaugeFill: tcell.NewRGBColor(0xA3, 0xBE, 0x8C),
+		Border:    tcell.NewRGBColor(0xE5, 0xE9, 0xF0),
+	},
+}
+
+// DefaultColorscheme is used whenever --color names an unknown scheme.
+const DefaultColorscheme = "default"
+
+// usageWarnPercent is the threshold above which a usage percentage is drawn
+// in Warning instead of its normal color.
+const usageWarnPercent = 85.0
+
+// tag renders c as a tview inline color tag, e.g. "[#ffcc00]".
+func tag(c tcell.Color) string {
+	r, g, b := c.RGB()
+	return fmt.Sprintf("[#%02x%02x%02x]", r, g, b)
+}
+
+// warnTag wraps s in scheme.Warning if percent is at or above
+// usageWarnPercent, so high CPU/memory/disk/process usage stands out the
+// same way everywhere it's shown.
+func warnTag(scheme Colorscheme, percent float64, s string) string {
+	if percent < usageWarnPercent {
+		return s
+	}
+	return tag(scheme.Warning) + s + "[white]"
+}
@@ -0,0 +1,107 @@
+package widgets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the persisted shape of $HOME/.sysm.yaml. CLI flags that are
+// explicitly set override whatever is loaded here.
+type Config struct {
+	Rate     time.Duration `yaml:"rate"`
+	Color    string        `yaml:"color"`
+	PageSize int           `yaml:"page_size"`
+	PerCPU   bool          `yaml:"percpu"`
+	// Widgets lists which panels should start visible. "cpu", "memory",
+	// "disk", and "processes" are always shown and are listed here only for
+	// documentation; "network", "diskinfo", and "cpuinfo" are the optional
+	// panels otherwise toggled at runtime with n/d/c.
+	Widgets []string `yaml:"widgets"`
+}
+
+// defaultConfig is returned when no config file exists yet.
+func defaultConfig() Config {
+	return Config{
+		Rate:     time.Second,
+		Color:    DefaultColorscheme,
+		PageSize: 10,
+		PerCPU:   true,
+		Widgets:  []string{"cpu", "memory", "disk", "processes"},
+	}
+}
+
+// DefaultConfigPath returns $HOME/.sysm.yaml.
+func DefaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".sysm.yaml"
+	}
+	return filepath.Join(home, ".sysm.yaml")
+}
+
+// LoadConfig reads path as YAML, falling back to defaultConfig for any field
+// missing from the file. A missing file is not an error.
+func LoadConfig(path string) (Config, error) {
+	cfg := defaultConfig()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// rawConfig mirrors Config but with Rate as a human-friendly duration
+// string (e.g. "1s", "500ms") since time.Duration doesn't unmarshal from
+// YAML on its own.
+type rawConfig struct {
+	Rate     string   `yaml:"rate"`
+	Color    string   `yaml:"color"`
+	PageSize int      `yaml:"page_size"`
+	PerCPU   *bool    `yaml:"percpu"`
+	Widgets  []string `yaml:"widgets"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler so Rate can be written as a
+// duration string in the config file.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw rawConfig
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	if raw.Rate != "" {
+		d, err := time.ParseDuration(raw.Rate)
+		if err != nil {
+			return fmt.Errorf("invalid rate %q: %w", raw.Rate, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("invalid rate %q: must be positive", raw.Rate)
+		}
+		c.Rate = d
+	}
+	if raw.Color != "" {
+		c.Color = raw.Color
+	}
+	if raw.PageSize != 0 {
+		c.PageSize = raw.PageSize
+	}
+	if raw.PerCPU != nil {
+		c.PerCPU = *raw.PerCPU
+	}
+	if raw.Widgets != nil {
+		c.Widgets = raw.Widgets
+	}
+	return nil
+}
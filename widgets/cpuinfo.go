@@ -0,0 +1,43 @@
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+var cpuInfoColumns = []string{"Core", "User", "Sys", "Nice", "Iowait", "Irq", "Softirq", "Steal", "Idle"}
+
+// updateCPUInfoTable repopulates table with one row per core from rows.
+func updateCPUInfoTable(table *tview.Table, rows []cpuStateRow, scheme Colorscheme) {
+	table.Clear()
+	table.SetBordersColor(scheme.Border)
+	headerTag := tag(scheme.Header)
+	for col, name := range cpuInfoColumns {
+		table.SetCell(0, col, tview.NewTableCell(headerTag+name).SetAlign(tview.AlignCenter))
+	}
+
+	for i, row := range rows {
+		table.SetCell(i+1, 0, tview.NewTableCell(fmt.Sprintf("%d", row.Core)).SetAlign(tview.AlignCenter))
+		table.SetCell(i+1, 1, tview.NewTableCell(fmt.Sprintf("%.1f%%", row.User)).SetAlign(tview.AlignCenter))
+		table.SetCell(i+1, 2, tview.NewTableCell(fmt.Sprintf("%.1f%%", row.System)).SetAlign(tview.AlignCenter))
+		table.SetCell(i+1, 3, tview.NewTableCell(fmt.Sprintf("%.1f%%", row.Nice)).SetAlign(tview.AlignCenter))
+		table.SetCell(i+1, 4, warnCell(fmt.Sprintf("%.1f%%", row.Iowait), row.Iowait, scheme))
+		table.SetCell(i+1, 5, tview.NewTableCell(fmt.Sprintf("%.1f%%", row.Irq)).SetAlign(tview.AlignCenter))
+		table.SetCell(i+1, 6, tview.NewTableCell(fmt.Sprintf("%.1f%%", row.Softirq)).SetAlign(tview.AlignCenter))
+		table.SetCell(i+1, 7, warnCell(fmt.Sprintf("%.1f%%", row.Steal), row.Steal, scheme))
+		table.SetCell(i+1, 8, tview.NewTableCell(fmt.Sprintf("%.1f%%", row.Idle)).SetAlign(tview.AlignCenter))
+	}
+}
+
+// warnCell builds a centered table cell, colored with scheme.Warning once
+// percent crosses usageWarnPercent. Iowait and steal are the states worth
+// flagging here: sustained time in either usually means the core is
+// starved, not busy.
+func warnCell(text string, percent float64, scheme Colorscheme) *tview.TableCell {
+	cell := tview.NewTableCell(text).SetAlign(tview.AlignCenter)
+	if percent >= usageWarnPercent {
+		cell.SetTextColor(scheme.Warning)
+	}
+	return cell
+}
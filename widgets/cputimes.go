@@ -0,0 +1,73 @@
+package widgets
+
+import (
+	"github.com/shirou/gopsutil/cpu"
+)
+
+// cpuTimesTracker keeps the previous per-core cpu.TimesStat snapshot so it
+// can compute the percentage of time spent in each state between samples.
+type cpuTimesTracker struct {
+	prev []cpu.TimesStat
+}
+
+// cpuStateRow is one core's percentage breakdown across states.
+type cpuStateRow struct {
+	Core    int
+	User    float64
+	System  float64
+	Nice    float64
+	Iowait  float64
+	Irq     float64
+	Softirq float64
+	Steal   float64
+	Idle    float64
+}
+
+func newCPUTimesTracker() *cpuTimesTracker {
+	return &cpuTimesTracker{}
+}
+
+// sample returns the per-core state breakdown since the previous call. The
+// first call has nothing to diff against and returns no rows.
+func (t *cpuTimesTracker) sample() ([]cpuStateRow, error) {
+	cur, err := cpu.Times(true)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := cpuStateRows(cur, t.prev)
+	t.prev = cur
+	return rows, nil
+}
+
+// cpuStateRows computes the per-core state percentage breakdown between two
+// cpu.Times snapshots. It returns nil if prev is nil or the core count
+// changed between samples (e.g. the first call, or a core hotplug).
+func cpuStateRows(cur, prev []cpu.TimesStat) []cpuStateRow {
+	if prev == nil || len(prev) != len(cur) {
+		return nil
+	}
+
+	rows := make([]cpuStateRow, len(cur))
+	for i, c := range cur {
+		p := prev[i]
+		total := cpuTimesTotal(c) - cpuTimesTotal(p)
+		row := cpuStateRow{Core: i}
+		if total > 0 {
+			row.User = (c.User - p.User) / total * 100
+			row.System = (c.System - p.System) / total * 100
+			row.Nice = (c.Nice - p.Nice) / total * 100
+			row.Iowait = (c.Iowait - p.Iowait) / total * 100
+			row.Irq = (c.Irq - p.Irq) / total * 100
+			row.Softirq = (c.Softirq - p.Softirq) / total * 100
+			row.Steal = (c.Steal - p.Steal) / total * 100
+			row.Idle = (c.Idle - p.Idle) / total * 100
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+func cpuTimesTotal(t cpu.TimesStat) float64 {
+	return t.User + t.System + t.Nice + t.Idle + t.Iowait + t.Irq + t.Softirq + t.Steal
+}
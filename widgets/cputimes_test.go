@@ -0,0 +1,73 @@
+package widgets
+
+import (
+	"testing"
+
+	"github.com/shirou/gopsutil/cpu"
+)
+
+func TestCPUStateRowsFirstSampleHasNoPrev(t *testing.T) {
+	cur := []cpu.TimesStat{{CPU: "cpu0", User: 10}}
+	if rows := cpuStateRows(cur, nil); rows != nil {
+		t.Errorf("cpuStateRows(cur, nil) = %v, want nil", rows)
+	}
+}
+
+func TestCPUStateRowsCoreCountChanged(t *testing.T) {
+	prev := []cpu.TimesStat{{CPU: "cpu0"}}
+	cur := []cpu.TimesStat{{CPU: "cpu0"}, {CPU: "cpu1"}}
+	if rows := cpuStateRows(cur, prev); rows != nil {
+		t.Errorf("cpuStateRows with changed core count = %v, want nil", rows)
+	}
+}
+
+func TestCPUStateRowsDelta(t *testing.T) {
+	prev := []cpu.TimesStat{{
+		CPU: "cpu0", User: 100, System: 50, Idle: 800,
+	}}
+	cur := []cpu.TimesStat{{
+		CPU: "cpu0", User: 120, System: 55, Idle: 825,
+	}}
+
+	rows := cpuStateRows(cur, prev)
+	if len(rows) != 1 {
+		t.Fatalf("len(rows) = %d, want 1", len(rows))
+	}
+
+	// delta: user=20, system=5, idle=25, total=50
+	row := rows[0]
+	if row.Core != 0 {
+		t.Errorf("Core = %d, want 0", row.Core)
+	}
+	if got, want := row.User, 40.0; got != want {
+		t.Errorf("User = %v, want %v", got, want)
+	}
+	if got, want := row.System, 10.0; got != want {
+		t.Errorf("System = %v, want %v", got, want)
+	}
+	if got, want := row.Idle, 50.0; got != want {
+		t.Errorf("Idle = %v, want %v", got, want)
+	}
+}
+
+func TestCPUStateRowsZeroTotalDeltaLeavesZeroRow(t *testing.T) {
+	same := []cpu.TimesStat{{CPU: "cpu0", User: 100, System: 50, Idle: 800}}
+
+	rows := cpuStateRows(same, same)
+	if len(rows) != 1 {
+		t.Fatalf("len(rows) = %d, want 1", len(rows))
+	}
+	if rows[0] != (cpuStateRow{Core: 0}) {
+		t.Errorf("rows[0] = %+v, want zero breakdown", rows[0])
+	}
+}
+
+func TestCPUTimesTotal(t *testing.T) {
+	stat := cpu.TimesStat{
+		User: 1, System: 2, Nice: 3, Idle: 4,
+		Iowait: 5, Irq: 6, Softirq: 7, Steal: 8,
+	}
+	if got, want := cpuTimesTotal(stat), 36.0; got != want {
+		t.Errorf("cpuTimesTotal(%+v) = %v, want %v", stat, got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package widgets
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+const diskBarWidth = 10
+
+// renderUsageBar draws a simple filled/empty block bar for a percentage,
+// colored with scheme.GaugeFill, or scheme.Warning once percent crosses
+// usageWarnPercent.
+func renderUsageBar(percent float64, scheme Colorscheme) string {
+	filled := int(percent / 100 * diskBarWidth)
+	if filled > diskBarWidth {
+		filled = diskBarWidth
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", diskBarWidth-filled)
+
+	color := scheme.GaugeFill
+	if percent >= usageWarnPercent {
+		color = scheme.Warning
+	}
+	return tag(color) + bar + "[white]"
+}
+
+// updateDiskTable repopulates table with one row per mounted partition,
+// showing total/used/free space and a usage bar.
+func updateDiskTable(table *tview.Table, scheme Colorscheme, partitions []DiskPartitionUsage) {
+	table.Clear()
+	table.SetBordersColor(scheme.Border)
+	headerTag := tag(scheme.Header)
+
+	table.SetCell(0, 0, tview.NewTableCell(headerTag+"Mount").SetAlign(tview.AlignLeft))
+	table.SetCell(0, 1, tview.NewTableCell(headerTag+"Total").SetAlign(tview.AlignCenter))
+	table.SetCell(0, 2, tview.NewTableCell(headerTag+"Used").SetAlign(tview.AlignCenter))
+	table.SetCell(0, 3, tview.NewTableCell(headerTag+"Free").SetAlign(tview.AlignCenter))
+	table.SetCell(0, 4, tview.NewTableCell(headerTag+"Usage").SetAlign(tview.AlignLeft))
+
+	for i, p := range partitions {
+		row := i + 1
+		table.SetCell(row, 0, tview.NewTableCell(p.Mountpoint).SetAlign(tview.AlignLeft))
+		table.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%.1f GB", float64(p.Total)/1024/1024/1024)).SetAlign(tview.AlignCenter))
+		table.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%.1f GB", float64(p.Used)/1024/1024/1024)).SetAlign(tview.AlignCenter))
+		table.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%.1f GB", float64(p.Free)/1024/1024/1024)).SetAlign(tview.AlignCenter))
+		table.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%s %.1f%%", renderUsageBar(p.UsedPercent, scheme), p.UsedPercent)).SetAlign(tview.AlignLeft))
+	}
+}
@@ -0,0 +1,68 @@
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// sparkChars are used low-to-high to render a single-row sparkline.
+var sparkChars = []rune(" ▁▂▃▄▅▆▇█")
+
+// LineGraph is a tview.Primitive that draws a labelled sparkline for a
+// slice of float64 samples scaled to a fixed 0-100 range. It's intentionally
+// simpler than a full braille line graph (gotop's widgets/cpu.go) since the
+// terminal widths sysm targets are usually too narrow to benefit from one.
+type LineGraph struct {
+	*tview.Box
+
+	title string
+	data  func() []float64
+}
+
+// NewLineGraph creates a LineGraph with the given title. source is called on
+// every Draw to fetch the latest samples, so the graph always reflects the
+// Hub's current history without the caller needing to push updates.
+func NewLineGraph(title string, source func() []float64) *LineGraph {
+	return &LineGraph{
+		Box:   tview.NewBox().SetBorder(true).SetTitle(" " + title + " "),
+		title: title,
+		data:  source,
+	}
+}
+
+// Draw renders the sparkline and the latest value into the box.
+func (g *LineGraph) Draw(screen tcell.Screen) {
+	g.Box.DrawForSubclass(screen, g)
+	x, y, width, height := g.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	samples := g.data()
+	if len(samples) == 0 {
+		return
+	}
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+
+	style := tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	row := y + height - 1
+	col := x
+	for _, v := range samples {
+		if v < 0 {
+			v = 0
+		}
+		if v > 100 {
+			v = 100
+		}
+		idx := int(v / 100 * float64(len(sparkChars)-1))
+		screen.SetContent(col, row, sparkChars[idx], nil, style)
+		col++
+	}
+
+	latest := fmt.Sprintf("%.1f", samples[len(samples)-1])
+	tview.Print(screen, latest, x, y, width, tview.AlignRight, tcell.ColorWhite)
+}
@@ -0,0 +1,35 @@
+package widgets
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// errLog writes to $XDG_CONFIG_HOME/sysm/errors.log (falling back to
+// ~/.config/sysm/errors.log) so failures that used to be silently swallowed
+// with "_ =" leave a trail instead of vanishing.
+var errLog = newErrorLogger()
+
+func newErrorLogger() *log.Logger {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return log.New(io.Discard, "", 0)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	dir = filepath.Join(dir, "sysm")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return log.New(io.Discard, "", 0)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, "errors.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return log.New(io.Discard, "", 0)
+	}
+	return log.New(f, "", log.LstdFlags)
+}
@@ -0,0 +1,9 @@
+package widgets
+
+import "fmt"
+
+// getNetworkUsage renders a sample's network rx/tx throughput as text. The
+// rates themselves are computed once per tick by the Hub.
+func getNetworkUsage(s Sample) string {
+	return fmt.Sprintf("Network I/O:\nRx: %.2f KB/s\nTx: %.2f KB/s", s.NetRxBytes/1024, s.NetTxBytes/1024)
+}
@@ -0,0 +1,113 @@
+package widgets
+
+import (
+	"errors"
+	"io/fs"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shirou/gopsutil/process"
+)
+
+// ProcessInfo is a typed snapshot of a single process, fetched via
+// process.Process methods instead of the old formatted-string rows, so
+// sorting and filtering can operate on real fields.
+type ProcessInfo struct {
+	PID     int32
+	Name    string
+	CPU     float64
+	Mem     float32
+	User    string
+	Cmdline string
+	Status  string
+}
+
+// fetchProcesses lists every visible process as a ProcessInfo. Fields that
+// fail to resolve (e.g. a process that exited mid-scan, or one we don't have
+// permission to inspect) are left at their zero value rather than aborting
+// the whole scan.
+func fetchProcesses() ([]ProcessInfo, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]ProcessInfo, 0, len(procs))
+	for _, p := range procs {
+		name, err := p.Name()
+		if err != nil {
+			logProcessErrOnce("Name", p.Pid, err)
+		}
+		cpuPercent, err := p.CPUPercent()
+		if err != nil {
+			logProcessErrOnce("CPUPercent", p.Pid, err)
+		}
+		memPercent, err := p.MemoryPercent()
+		if err != nil {
+			logProcessErrOnce("MemoryPercent", p.Pid, err)
+		}
+		user, err := p.Username()
+		if err != nil {
+			logProcessErrOnce("Username", p.Pid, err)
+		}
+		cmdline, err := p.Cmdline()
+		if err != nil {
+			logProcessErrOnce("Cmdline", p.Pid, err)
+		}
+		status, err := p.Status()
+		if err != nil {
+			logProcessErrOnce("Status", p.Pid, err)
+		}
+
+		infos = append(infos, ProcessInfo{
+			PID:     p.Pid,
+			Name:    name,
+			CPU:     cpuPercent,
+			Mem:     memPercent,
+			User:    user,
+			Cmdline: cmdline,
+			Status:  status,
+		})
+	}
+	return infos, nil
+}
+
+var (
+	procErrMu     sync.Mutex
+	loggedProcErr = make(map[string]bool)
+)
+
+// logProcessErrOnce logs a per-field process scan failure the first time a
+// given field/error combination is seen, then suppresses repeats. These
+// failures (a process exiting mid-scan, a permission-denied read on another
+// user's process) are routine on every tick, not exceptional, so logging
+// them unconditionally would fill errors.log at tens of lines per second on
+// a busy box. The key is deduped against the pid (see dedupErr), since
+// gopsutil's errors are *fs.PathErrors reading /proc/<pid>/... and a raw
+// err.Error() would embed a different pid every tick for the exact
+// short-lived-process case this is meant to catch.
+func logProcessErrOnce(field string, pid int32, err error) {
+	key := field + ": " + dedupErr(pid, err)
+
+	procErrMu.Lock()
+	defer procErrMu.Unlock()
+	if loggedProcErr[key] {
+		return
+	}
+	loggedProcErr[key] = true
+	errLog.Printf("pid %d: %s: %v (further occurrences of this kind of error are suppressed)", pid, field, err)
+}
+
+// dedupErr reduces err to a pid-independent string. Most gopsutil process
+// errors are *fs.PathError wrapping /proc/<pid>/..., so comparing just the
+// operation and underlying errno dedupes "process exited mid-scan" across
+// every pid it happens to; anything else falls back to stripping the pid's
+// decimal digits out of the error text.
+func dedupErr(pid int32, err error) string {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Op + ": " + pathErr.Err.Error()
+	}
+	return strings.ReplaceAll(err.Error(), strconv.Itoa(int(pid)), "<pid>")
+}
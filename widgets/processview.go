@@ -0,0 +1,244 @@
+package widgets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/shirou/gopsutil/process"
+)
+
+// sortField is which ProcessInfo column the table is currently ordered by.
+type sortField int
+
+const (
+	sortByCPU sortField = iota
+	sortByMem
+	sortByPID
+	sortByName
+)
+
+// ProcessView turns the process table into an interactive manager: sort
+// with 1-4, filter with '/', send a signal to the selected row with 'k'.
+type ProcessView struct {
+	app   *tview.Application
+	pages *tview.Pages
+	table *tview.Table
+
+	raw      []ProcessInfo // latest list from the Hub, unfiltered/unsorted
+	all      []ProcessInfo // raw after filter/sort/pageSize applied
+	sortBy   sortField
+	filter   string
+	scheme   Colorscheme
+	pageSize int
+}
+
+// NewProcessView builds the process table. The modal pages (search, kill
+// confirmation) are added on top of pages when they are opened. pageSize caps how
+// many rows are shown after sorting; 0 means unlimited.
+func NewProcessView(app *tview.Application, pages *tview.Pages, scheme Colorscheme, pageSize int) *ProcessView {
+	table := tview.NewTable().SetBorders(true).SetSelectable(true, false)
+	table.SetBordersColor(scheme.Border)
+
+	pv := &ProcessView{
+		app:      app,
+		pages:    pages,
+		table:    table,
+		sortBy:   sortByCPU,
+		scheme:   scheme,
+		pageSize: pageSize,
+	}
+	return pv
+}
+
+// Table returns the underlying table primitive to place in the layout.
+func (pv *ProcessView) Table() *tview.Table {
+	return pv.table
+}
+
+// Refresh stores the Hub's latest process list and redraws the table. The
+// Hub does the actual polling once per tick; ProcessView only sorts/filters
+// what it's handed.
+func (pv *ProcessView) Refresh(processes []ProcessInfo) {
+	pv.raw = processes
+	pv.applyFilterSort()
+}
+
+// applyFilterSort rebuilds pv.all from pv.raw using the current
+// filter/sort/pageSize settings and redraws the table, without re-polling.
+func (pv *ProcessView) applyFilterSort() {
+	infos := append([]ProcessInfo(nil), pv.raw...)
+
+	if pv.filter != "" {
+		filtered := infos[:0:0]
+		for _, p := range infos {
+			if strings.Contains(strings.ToLower(p.Name), strings.ToLower(pv.filter)) {
+				filtered = append(filtered, p)
+			}
+		}
+		infos = filtered
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		switch pv.sortBy {
+		case sortByMem:
+			return infos[i].Mem > infos[j].Mem
+		case sortByPID:
+			return infos[i].PID < infos[j].PID
+		case sortByName:
+			return infos[i].Name < infos[j].Name
+		default:
+			return infos[i].CPU > infos[j].CPU
+		}
+	})
+
+	if pv.pageSize > 0 && len(infos) > pv.pageSize {
+		infos = infos[:pv.pageSize]
+	}
+
+	pv.all = infos
+	pv.render()
+}
+
+func (pv *ProcessView) render() {
+	row, col := pv.table.GetSelection()
+
+	pv.table.Clear()
+	headerTag := tag(pv.scheme.Header)
+	headers := []string{"PID", "Name", "CPU %", "Mem %", "User", "Status"}
+	for c, h := range headers {
+		pv.table.SetCell(0, c, tview.NewTableCell(headerTag+h).SetAlign(tview.AlignCenter).SetSelectable(false))
+	}
+
+	for i, p := range pv.all {
+		cpuCell := tview.NewTableCell(fmt.Sprintf("%.2f%%", p.CPU)).SetAlign(tview.AlignCenter)
+		if p.CPU >= usageWarnPercent {
+			cpuCell.SetTextColor(pv.scheme.Warning)
+		}
+		memCell := tview.NewTableCell(fmt.Sprintf("%.2f%%", p.Mem)).SetAlign(tview.AlignCenter)
+		if float64(p.Mem) >= usageWarnPercent {
+			memCell.SetTextColor(pv.scheme.Warning)
+		}
+
+		pv.table.SetCell(i+1, 0, tview.NewTableCell(fmt.Sprintf("%d", p.PID)).SetAlign(tview.AlignCenter))
+		pv.table.SetCell(i+1, 1, tview.NewTableCell(p.Name).SetAlign(tview.AlignLeft))
+		pv.table.SetCell(i+1, 2, cpuCell)
+		pv.table.SetCell(i+1, 3, memCell)
+		pv.table.SetCell(i+1, 4, tview.NewTableCell(p.User).SetAlign(tview.AlignCenter))
+		pv.table.SetCell(i+1, 5, tview.NewTableCell(p.Status).SetAlign(tview.AlignCenter))
+	}
+
+	if row < 1 && len(pv.all) > 0 {
+		row = 1
+	}
+	if row > len(pv.all) {
+		row = len(pv.all)
+	}
+	pv.table.Select(row, col)
+}
+
+// selected returns the ProcessInfo for the currently highlighted row, if
+// any.
+func (pv *ProcessView) selected() (ProcessInfo, bool) {
+	row, _ := pv.table.GetSelection()
+	idx := row - 1
+	if idx < 0 || idx >= len(pv.all) {
+		return ProcessInfo{}, false
+	}
+	return pv.all[idx], true
+}
+
+// HandleKey processes sort/filter/kill shortcuts. It returns false if the
+// event wasn't one of ours and should keep propagating.
+func (pv *ProcessView) HandleKey(event *tcell.EventKey) bool {
+	if event.Key() != tcell.KeyRune {
+		return false
+	}
+
+	switch event.Rune() {
+	case '1':
+		pv.sortBy = sortByCPU
+	case '2':
+		pv.sortBy = sortByMem
+	case '3':
+		pv.sortBy = sortByPID
+	case '4':
+		pv.sortBy = sortByName
+	case '/':
+		pv.showSearch()
+		return true
+	case 'k':
+		pv.showKillConfirm()
+		return true
+	default:
+		return false
+	}
+
+	pv.applyFilterSort()
+	return true
+}
+
+func (pv *ProcessView) showSearch() {
+	input := tview.NewInputField().
+		SetLabel("Search: ").
+		SetText(pv.filter)
+
+	input.SetDoneFunc(func(key tcell.Key) {
+		if key == tcell.KeyEnter {
+			pv.filter = input.GetText()
+			pv.applyFilterSort()
+		}
+		pv.pages.RemovePage("search")
+		pv.app.SetFocus(pv.table)
+	})
+
+	modal := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(nil, 0, 1, false).
+			AddItem(input, 0, 2, true).
+			AddItem(nil, 0, 1, false), 3, 1, true).
+		AddItem(nil, 0, 1, false)
+
+	pv.pages.AddPage("search", modal, true, true)
+	pv.app.SetFocus(input)
+}
+
+func (pv *ProcessView) showKillConfirm() {
+	target, ok := pv.selected()
+	if !ok {
+		return
+	}
+
+	modal := tview.NewModal().
+		SetText(fmt.Sprintf("Send a signal to PID %d (%s)?", target.PID, target.Name)).
+		AddButtons([]string{"SIGTERM", "SIGKILL", "Cancel"}).
+		SetDoneFunc(func(_ int, label string) {
+			pv.pages.RemovePage("confirm-kill")
+			pv.app.SetFocus(pv.table)
+
+			switch label {
+			case "SIGTERM":
+				pv.signal(target.PID, (*process.Process).Terminate)
+			case "SIGKILL":
+				pv.signal(target.PID, (*process.Process).Kill)
+			}
+		})
+
+	pv.pages.AddPage("confirm-kill", modal, true, true)
+	pv.app.SetFocus(modal)
+}
+
+func (pv *ProcessView) signal(pid int32, send func(*process.Process) error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		errLog.Printf("pid %d: signal: NewProcess: %v", pid, err)
+		return
+	}
+	if err := send(p); err != nil {
+		errLog.Printf("pid %d: signal: %v", pid, err)
+	}
+	pv.applyFilterSort()
+}
@@ -0,0 +1,37 @@
+package widgets
+
+// RingBuffer is a fixed-size rolling buffer of float64 samples. Once full,
+// pushing a new value overwrites the oldest one.
+type RingBuffer struct {
+	data []float64
+	next int
+	full bool
+}
+
+// NewRingBuffer allocates a ring buffer that holds up to size samples.
+func NewRingBuffer(size int) *RingBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &RingBuffer{data: make([]float64, size)}
+}
+
+// Push appends a sample, evicting the oldest one once the buffer is full.
+func (r *RingBuffer) Push(v float64) {
+	r.data[r.next] = v
+	r.next = (r.next + 1) % len(r.data)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// Values returns the buffered samples in chronological order (oldest first).
+func (r *RingBuffer) Values() []float64 {
+	if !r.full {
+		return append([]float64(nil), r.data[:r.next]...)
+	}
+	out := make([]float64, 0, len(r.data))
+	out = append(out, r.data[r.next:]...)
+	out = append(out, r.data[:r.next]...)
+	return out
+}
@@ -0,0 +1,54 @@
+package widgets
+
+import "testing"
+
+func TestRingBufferBeforeFull(t *testing.T) {
+	r := NewRingBuffer(4)
+	r.Push(1)
+	r.Push(2)
+
+	got := r.Values()
+	want := []float64{1, 2}
+	if !floatsEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestRingBufferWraparound(t *testing.T) {
+	r := NewRingBuffer(3)
+	r.Push(1)
+	r.Push(2)
+	r.Push(3)
+	r.Push(4) // evicts 1
+	r.Push(5) // evicts 2
+
+	got := r.Values()
+	want := []float64{3, 4, 5}
+	if !floatsEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func TestRingBufferZeroSizeClampsToOne(t *testing.T) {
+	r := NewRingBuffer(0)
+	r.Push(1)
+	r.Push(2)
+
+	got := r.Values()
+	want := []float64{2}
+	if !floatsEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+}
+
+func floatsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
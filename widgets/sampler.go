@@ -0,0 +1,308 @@
+package widgets
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/cpu"
+	"github.com/shirou/gopsutil/disk"
+	"github.com/shirou/gopsutil/mem"
+	"github.com/shirou/gopsutil/net"
+)
+
+// DiskPartitionUsage is one mounted filesystem's space usage.
+type DiskPartitionUsage struct {
+	Mountpoint  string
+	Total       uint64
+	Used        uint64
+	Free        uint64
+	UsedPercent float64
+}
+
+// Sample is a single point-in-time reading fanned out to subscribers. It
+// carries everything both the TUI and the HTTP endpoints need, so only one
+// goroutine ever has to poll gopsutil per tick.
+type Sample struct {
+	CPUPerCore     []float64
+	MemPercent     float64
+	MemTotal       uint64
+	MemUsed        uint64
+	MemFree        uint64
+	SwapPercent    float64
+	DiskReadBytes  float64 // bytes/sec, summed across disks
+	DiskWriteBytes float64 // bytes/sec, summed across disks
+	NetRxBytes     float64 // bytes/sec
+	NetTxBytes     float64 // bytes/sec
+	DiskUsage      []DiskPartitionUsage
+	Processes      []ProcessInfo
+}
+
+// Hub owns the 1-tick sampling loop and keeps a rolling history of each
+// metric so history widgets can draw a line graph without re-polling, plus
+// an RLock-protected copy of the latest full Sample for the TUI and the HTTP
+// metrics endpoints to share.
+type Hub struct {
+	interval time.Duration
+	history  int
+
+	mu          sync.Mutex
+	cpuHistory  []*RingBuffer
+	memHistory  *RingBuffer
+	swapHistory *RingBuffer
+	diskRead    *RingBuffer
+	diskWrite   *RingBuffer
+
+	prevDiskIO  map[string]disk.IOCountersStat
+	prevNetIO   []net.IOCountersStat
+	prevSampled time.Time
+
+	latestMu sync.RWMutex
+	latest   Sample
+
+	subsMu sync.Mutex
+	subs   []chan Sample
+}
+
+// NewHub creates a Hub that keeps the last `history` samples of each metric,
+// sampled every interval.
+func NewHub(interval time.Duration, history int) *Hub {
+	return &Hub{
+		interval:    interval,
+		history:     history,
+		memHistory:  NewRingBuffer(history),
+		swapHistory: NewRingBuffer(history),
+		diskRead:    NewRingBuffer(history),
+		diskWrite:   NewRingBuffer(history),
+	}
+}
+
+// Subscribe returns a channel that receives samples taken from here on.
+// The channel holds one sample so a slow widget can't stall the sampling
+// loop; a sample that arrives while it is still full is dropped for that
+// subscriber.
+func (h *Hub) Subscribe() <-chan Sample {
+	ch := make(chan Sample, 1)
+	h.subsMu.Lock()
+	h.subs = append(h.subs, ch)
+	h.subsMu.Unlock()
+	return ch
+}
+
+// Snapshot returns a copy of the most recently taken Sample.
+func (h *Hub) Snapshot() Sample {
+	h.latestMu.RLock()
+	defer h.latestMu.RUnlock()
+	return h.latest
+}
+
+// Run samples forever until stop is closed. It's meant to be run in its own
+// goroutine.
+func (h *Hub) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			h.tick()
+		}
+	}
+}
+
+func (h *Hub) tick() {
+	s := h.sample()
+	h.record(s)
+
+	h.latestMu.Lock()
+	h.latest = s
+	h.latestMu.Unlock()
+
+	h.subsMu.Lock()
+	defer h.subsMu.Unlock()
+	for _, ch := range h.subs {
+		select {
+		case ch <- s:
+		default:
+		}
+	}
+}
+
+func (h *Hub) sample() Sample {
+	var s Sample
+
+	if percents, err := cpu.Percent(0, true); err == nil {
+		s.CPUPerCore = percents
+	} else {
+		errLog.Printf("sample: cpu.Percent: %v", err)
+	}
+
+	if v, err := mem.VirtualMemory(); err == nil {
+		s.MemPercent = v.UsedPercent
+		s.MemTotal = v.Total
+		s.MemUsed = v.Used
+		s.MemFree = v.Free
+	} else {
+		errLog.Printf("sample: mem.VirtualMemory: %v", err)
+	}
+	if sw, err := mem.SwapMemory(); err == nil {
+		s.SwapPercent = sw.UsedPercent
+	} else {
+		errLog.Printf("sample: mem.SwapMemory: %v", err)
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(h.prevSampled).Seconds()
+
+	if counters, err := disk.IOCounters(); err == nil {
+		var readBytes, writeBytes uint64
+		for _, c := range counters {
+			readBytes += c.ReadBytes
+			writeBytes += c.WriteBytes
+		}
+		if h.prevDiskIO != nil && elapsed > 0 {
+			var prevRead, prevWrite uint64
+			for _, c := range h.prevDiskIO {
+				prevRead += c.ReadBytes
+				prevWrite += c.WriteBytes
+			}
+			s.DiskReadBytes = float64(readBytes-prevRead) / elapsed
+			s.DiskWriteBytes = float64(writeBytes-prevWrite) / elapsed
+		}
+		h.prevDiskIO = counters
+	} else {
+		errLog.Printf("sample: disk.IOCounters: %v", err)
+	}
+
+	if counters, err := net.IOCounters(false); err == nil && len(counters) > 0 {
+		cur := counters[0]
+		if len(h.prevNetIO) > 0 && elapsed > 0 {
+			prev := h.prevNetIO[0]
+			s.NetRxBytes = float64(cur.BytesRecv-prev.BytesRecv) / elapsed
+			s.NetTxBytes = float64(cur.BytesSent-prev.BytesSent) / elapsed
+		}
+		h.prevNetIO = counters
+	} else if err != nil {
+		errLog.Printf("sample: net.IOCounters: %v", err)
+	}
+
+	h.prevSampled = now
+
+	if partitions, err := disk.Partitions(false); err == nil {
+		for _, p := range partitions {
+			usage, err := disk.Usage(p.Mountpoint)
+			if err != nil {
+				errLog.Printf("sample: disk.Usage(%s): %v", p.Mountpoint, err)
+				continue
+			}
+			s.DiskUsage = append(s.DiskUsage, DiskPartitionUsage{
+				Mountpoint:  p.Mountpoint,
+				Total:       usage.Total,
+				Used:        usage.Used,
+				Free:        usage.Free,
+				UsedPercent: usage.UsedPercent,
+			})
+		}
+	} else {
+		errLog.Printf("sample: disk.Partitions: %v", err)
+	}
+
+	if procs, err := fetchProcesses(); err == nil {
+		s.Processes = procs
+	} else {
+		errLog.Printf("sample: fetchProcesses: %v", err)
+	}
+
+	return s
+}
+
+func (h *Hub) record(s Sample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.cpuHistory) != len(s.CPUPerCore) {
+		h.cpuHistory = make([]*RingBuffer, len(s.CPUPerCore))
+		for i := range h.cpuHistory {
+			h.cpuHistory[i] = NewRingBuffer(h.history)
+		}
+	}
+	for i, p := range s.CPUPerCore {
+		h.cpuHistory[i].Push(p)
+	}
+	h.memHistory.Push(s.MemPercent)
+	h.swapHistory.Push(s.SwapPercent)
+	h.diskRead.Push(s.DiskReadBytes)
+	h.diskWrite.Push(s.DiskWriteBytes)
+}
+
+// CPUCoreHistory returns the history buffer for a single core.
+func (h *Hub) CPUCoreHistory(core int) []float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if core < 0 || core >= len(h.cpuHistory) {
+		return nil
+	}
+	return h.cpuHistory[core].Values()
+}
+
+// CPUAverageHistory averages all cores sample-by-sample, for use once the
+// core count is too high to usefully plot individually.
+func (h *Hub) CPUAverageHistory() []float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.cpuHistory) == 0 {
+		return nil
+	}
+	per := make([][]float64, len(h.cpuHistory))
+	longest := 0
+	for i, rb := range h.cpuHistory {
+		per[i] = rb.Values()
+		if len(per[i]) > longest {
+			longest = len(per[i])
+		}
+	}
+	avg := make([]float64, longest)
+	counts := make([]int, longest)
+	for _, vals := range per {
+		offset := longest - len(vals)
+		for i, v := range vals {
+			avg[offset+i] += v
+			counts[offset+i]++
+		}
+	}
+	for i, c := range counts {
+		if c > 0 {
+			avg[i] /= float64(c)
+		}
+	}
+	return avg
+}
+
+// CPUCoreCount reports how many cores the most recent sample covered.
+func (h *Hub) CPUCoreCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.cpuHistory)
+}
+
+// MemHistory returns the memory usage history.
+func (h *Hub) MemHistory() []float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.memHistory.Values()
+}
+
+// SwapHistory returns the swap usage history.
+func (h *Hub) SwapHistory() []float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.swapHistory.Values()
+}
+
+// DiskIOHistory returns the disk read/write throughput histories, in
+// bytes/sec.
+func (h *Hub) DiskIOHistory() (read, write []float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.diskRead.Values(), h.diskWrite.Values()
+}
@@ -0,0 +1,99 @@
+package widgets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// metricsDoc is the JSON shape served at /metrics. It regroups Sample's
+// fields under cpu/memory/disk/network/processes rather than re-exporting
+// Sample directly, so the wire format can evolve independently of the Hub's
+// internal representation. Disk read/write throughput is not included.
+type metricsDoc struct {
+	CPU       []float64            `json:"cpu"`
+	Memory    metricsMemory        `json:"memory"`
+	Disk      []DiskPartitionUsage `json:"disk"`
+	Network   metricsNetwork       `json:"network"`
+	Processes []ProcessInfo        `json:"processes"`
+}
+
+type metricsMemory struct {
+	UsedPercent float64 `json:"used_percent"`
+	Total       uint64  `json:"total"`
+	Used        uint64  `json:"used"`
+	Free        uint64  `json:"free"`
+	SwapPercent float64 `json:"swap_percent"`
+}
+
+type metricsNetwork struct {
+	RxBytesPerSec float64 `json:"rx_bytes_per_sec"`
+	TxBytesPerSec float64 `json:"tx_bytes_per_sec"`
+}
+
+func toMetricsDoc(s Sample) metricsDoc {
+	return metricsDoc{
+		CPU: s.CPUPerCore,
+		Memory: metricsMemory{
+			UsedPercent: s.MemPercent,
+			Total:       s.MemTotal,
+			Used:        s.MemUsed,
+			Free:        s.MemFree,
+			SwapPercent: s.SwapPercent,
+		},
+		Disk: s.DiskUsage,
+		Network: metricsNetwork{
+			RxBytesPerSec: s.NetRxBytes,
+			TxBytesPerSec: s.NetTxBytes,
+		},
+		Processes: s.Processes,
+	}
+}
+
+// NewMetricsServer builds an HTTP server exposing hub's latest Sample for
+// remote scraping: /metrics as JSON, /metrics/prom as Prometheus text. It
+// reads hub.Snapshot() rather than subscribing, so running it alongside the
+// TUI never causes the 1s polling loop to run twice.
+func NewMetricsServer(addr string, hub *Hub) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toMetricsDoc(hub.Snapshot())); err != nil {
+			errLog.Printf("serve /metrics: %v", err)
+		}
+	})
+	mux.HandleFunc("/metrics/prom", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writePrometheus(w, hub.Snapshot())
+	})
+
+	return &http.Server{Addr: addr, Handler: mux}
+}
+
+// writePrometheus renders a Sample in Prometheus text exposition format.
+func writePrometheus(w http.ResponseWriter, s Sample) {
+	fmt.Fprintf(w, "# HELP sysm_cpu_percent Per-core CPU usage percentage.\n")
+	fmt.Fprintf(w, "# TYPE sysm_cpu_percent gauge\n")
+	for i, p := range s.CPUPerCore {
+		fmt.Fprintf(w, "sysm_cpu_percent{core=\"%d\"} %f\n", i, p)
+	}
+
+	fmt.Fprintf(w, "# HELP sysm_mem_used_percent Memory usage percentage.\n")
+	fmt.Fprintf(w, "# TYPE sysm_mem_used_percent gauge\n")
+	fmt.Fprintf(w, "sysm_mem_used_percent %f\n", s.MemPercent)
+
+	fmt.Fprintf(w, "# HELP sysm_swap_used_percent Swap usage percentage.\n")
+	fmt.Fprintf(w, "# TYPE sysm_swap_used_percent gauge\n")
+	fmt.Fprintf(w, "sysm_swap_used_percent %f\n", s.SwapPercent)
+
+	fmt.Fprintf(w, "# HELP sysm_net_bytes_per_sec Network throughput in bytes/sec.\n")
+	fmt.Fprintf(w, "# TYPE sysm_net_bytes_per_sec gauge\n")
+	fmt.Fprintf(w, "sysm_net_bytes_per_sec{direction=\"rx\"} %f\n", s.NetRxBytes)
+	fmt.Fprintf(w, "sysm_net_bytes_per_sec{direction=\"tx\"} %f\n", s.NetTxBytes)
+
+	fmt.Fprintf(w, "# HELP sysm_disk_used_percent Disk usage percentage by mountpoint.\n")
+	fmt.Fprintf(w, "# TYPE sysm_disk_used_percent gauge\n")
+	for _, d := range s.DiskUsage {
+		fmt.Fprintf(w, "sysm_disk_used_percent{mountpoint=%q} %f\n", d.Mountpoint, d.UsedPercent)
+	}
+}
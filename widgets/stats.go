@@ -0,0 +1,37 @@
+package widgets
+
+import "fmt"
+
+// getCPUUsage renders a sample's per-core CPU percentages as text, with
+// scheme.Warning applied to any core over usageWarnPercent.
+func getCPUUsage(s Sample, scheme Colorscheme) string {
+	if s.CPUPerCore == nil {
+		return "Error fetching CPU stats"
+	}
+	cpuStats := "CPU Usage:\n"
+	for i, p := range s.CPUPerCore {
+		cpuStats += warnTag(scheme, p, fmt.Sprintf("Core %d: %.2f%%", i, p)) + "\n"
+	}
+	return cpuStats
+}
+
+// getMemoryUsage renders a sample's memory usage as text, with
+// scheme.Warning applied once usage crosses usageWarnPercent.
+func getMemoryUsage(s Sample, scheme Colorscheme) string {
+	headline := warnTag(scheme, s.MemPercent, fmt.Sprintf("Memory Usage: %.2f%%", s.MemPercent))
+	return fmt.Sprintf("%s\nTotal: %v MB\nUsed: %v MB\nFree: %v MB",
+		headline, s.MemTotal/1024/1024, s.MemUsed/1024/1024, s.MemFree/1024/1024)
+}
+
+// getDiskUsage renders a sample's usage for / as text, with scheme.Warning
+// applied once usage crosses usageWarnPercent.
+func getDiskUsage(s Sample, scheme Colorscheme) string {
+	for _, d := range s.DiskUsage {
+		if d.Mountpoint == "/" {
+			headline := warnTag(scheme, d.UsedPercent, fmt.Sprintf("Disk Usage: %.2f%%", d.UsedPercent))
+			return fmt.Sprintf("%s\nTotal: %v GB\nUsed: %v GB\nFree: %v GB",
+				headline, d.Total/1024/1024/1024, d.Used/1024/1024/1024, d.Free/1024/1024/1024)
+		}
+	}
+	return "Error fetching disk stats: / not found"
+}